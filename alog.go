@@ -7,6 +7,7 @@
 package alog
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"html/template"
@@ -16,6 +17,8 @@ import (
 	"path/filepath"
 	"runtime"
 	"runtime/debug"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/mylockerteam/mailSender"
@@ -23,12 +26,6 @@ import (
 	"gopkg.in/gomail.v2"
 )
 
-const (
-	messageFormatDefault      = "%s;%s\n"
-	messageFormatErrorDebug   = "%s\n%s\n---\n\n"
-	messageFormatWithFileLine = "%s;%s:%d;%s\n"
-)
-
 // Logger types
 const (
 	LoggerInfo uint = iota
@@ -49,19 +46,57 @@ var fs = afero.NewOsFs()
 
 //Logged interface for loggers
 type Logged interface {
+	Trace(msg string) *Log
+	Tracef(format string, p ...interface{}) *Log
+	Debug(msg string) *Log
+	Debugf(format string, p ...interface{}) *Log
 	Info(msg string) *Log
 	Infof(format string, p ...interface{}) *Log
 	Warning(msg string) *Log
 	Error(err error) *Log
 	ErrorDebug(err error) *Log
+	Fatal(msg string) *Log
+	Fatalf(format string, p ...interface{}) *Log
+	WithField(key string, value interface{}) *Log
+	WithFields(fields map[string]interface{}) *Log
 	GetLoggerInterfaceByType(loggerType uint) io.Writer
+	Close(ctx context.Context) error
+	Sync() error
 }
 
 // Logger logger structure which includes a channel and a slice strategies
 type Logger struct {
 	io.Writer
-	Channel    chan string
+	Channel    chan Entry
 	Strategies []io.Writer
+	// Formatter renders each Entry before it reaches Strategies. A nil
+	// Formatter falls back to TextFormatter, matching the historical
+	// "time;message" output.
+	Formatter Formatter
+
+	overflowPolicy  OverflowPolicy
+	overflowTimeout time.Duration
+
+	enqueued    uint64
+	processed   uint64
+	dropped     uint64
+	writeErrors uint64
+
+	dropWarnMu   sync.Mutex
+	lastDropWarn time.Time
+
+	wg sync.WaitGroup
+	// closeMu guards closed and serializes it with every channel send,
+	// so a send can never race close(Channel) into a panic.
+	closeMu sync.RWMutex
+	closed  bool
+}
+
+func (l *Logger) formatter() Formatter {
+	if l.Formatter != nil {
+		return l.Formatter
+	}
+	return &TextFormatter{}
 }
 
 // LoggerMap mapping for type:logger
@@ -72,12 +107,23 @@ type Config struct {
 	Loggers        LoggerMap
 	TimeFormat     string
 	IgnoreFileLine bool
+	// MinLevel suppresses messages below this severity before the
+	// fmt.Sprintf/newEntry/channel send for them ever runs.
+	MinLevel Level
+	// OverflowPolicy controls what happens when a Logger's Channel
+	// buffer is full. The zero value is OverflowBlock, matching the
+	// historical behavior.
+	OverflowPolicy OverflowPolicy
+	// OverflowTimeout is how long OverflowTimeout policy waits for room
+	// on the channel before dropping the entry.
+	OverflowTimeout time.Duration
 }
 
 // Log logger himself
 type Log struct {
 	_      Logged
 	config *Config
+	fields map[string]interface{}
 }
 
 // DefaultStrategy logging strategy in the console
@@ -97,9 +143,16 @@ type EmailStrategy struct {
 	sender   mailSender.AsyncSender
 	Message  *gomail.Message
 	Template *template.Template
+	minLevel Level
 	io.Writer
 }
 
+// MinLevel returns the severity below which the strategy is skipped,
+// implementing LeveledWriter.
+func (s *EmailStrategy) MinLevel() Level {
+	return s.minLevel
+}
+
 var loggerName = map[uint]string{
 	LoggerInfo: "Info",
 	LoggerWrn:  "Warning",
@@ -118,22 +171,13 @@ func LoggerName(code uint) string {
 
 // Writer interface for informational messages
 func (l *Logger) Write(p []byte) (n int, err error) {
-	if l == nil || isClosedCh(l.Channel) {
+	if l == nil || l.isClosed() {
 		return 0, errors.New("the channel was closed for recording")
 	}
-	l.Channel <- string(p)
+	l.enqueue(Entry{Time: time.Now(), Level: LevelInfo, Message: string(p)})
 	return len(p), nil
 }
 
-func isClosedCh(ch <-chan string) bool {
-	select {
-	case <-ch:
-		return true
-	default:
-		return false
-	}
-}
-
 // GetDefaultStrategy console write strategy
 func GetDefaultStrategy() io.Writer {
 	return &DefaultStrategy{}
@@ -164,12 +208,23 @@ func (s *FileStrategy) Write(p []byte) (n int, err error) {
 	return 0, errors.New("file not defined")
 }
 
+// Close closes the underlying file, implementing io.Closer.
+func (s *FileStrategy) Close() error {
+	if s.file != nil {
+		return s.file.Close()
+	}
+	return nil
+}
+
 //GetEmailStrategy waiting for a parameter ess in format host:port;username;password
-func GetEmailStrategy(sender mailSender.AsyncSender, msg *gomail.Message, tpl *template.Template) io.Writer {
+// minLevel is the severity below which the strategy is skipped, e.g.
+// LevelError so the mailbox only receives errors and above.
+func GetEmailStrategy(sender mailSender.AsyncSender, msg *gomail.Message, tpl *template.Template, minLevel Level) io.Writer {
 	return &EmailStrategy{
 		sender:   sender,
 		Message:  msg,
 		Template: tpl,
+		minLevel: minLevel,
 	}
 }
 
@@ -185,6 +240,12 @@ func (s *EmailStrategy) Write(p []byte) (n int, err error) {
 // Create creates an instance of the logger
 func Create(config *Config) Logged {
 	for _, logger := range config.Loggers {
+		if logger.Formatter == nil {
+			logger.Formatter = &TextFormatter{TimeFormat: config.TimeFormat}
+		}
+		logger.overflowPolicy = config.OverflowPolicy
+		logger.overflowTimeout = config.OverflowTimeout
+		logger.wg.Add(1)
 		go logger.reader()
 	}
 	return &Log{config: config}
@@ -196,19 +257,19 @@ func Default(chanBuffer uint) Logged {
 		TimeFormat: time.RFC3339Nano,
 		Loggers: LoggerMap{
 			LoggerInfo: &Logger{
-				Channel: make(chan string, chanBuffer),
+				Channel: make(chan Entry, chanBuffer),
 				Strategies: []io.Writer{
 					GetFileStrategy(os.Stdout.Name()),
 				},
 			},
 			LoggerWrn: &Logger{
-				Channel: make(chan string, chanBuffer),
+				Channel: make(chan Entry, chanBuffer),
 				Strategies: []io.Writer{
 					GetFileStrategy(os.Stdout.Name()),
 				},
 			},
 			LoggerErr: &Logger{
-				Channel: make(chan string, chanBuffer),
+				Channel: make(chan Entry, chanBuffer),
 				Strategies: []io.Writer{
 					GetFileStrategy(os.Stderr.Name()),
 				},
@@ -216,20 +277,31 @@ func Default(chanBuffer uint) Logged {
 		},
 	}
 	for _, logger := range config.Loggers {
+		logger.Formatter = &TextFormatter{TimeFormat: config.TimeFormat}
+		logger.overflowPolicy = config.OverflowPolicy
+		logger.overflowTimeout = config.OverflowTimeout
+		logger.wg.Add(1)
 		go logger.reader()
 	}
 	return &Log{config: config}
 }
 
 func (l *Logger) reader() {
-	for msg := range l.Channel {
-		l.writeMessage(msg)
+	defer l.wg.Done()
+	for entry := range l.Channel {
+		l.writeMessage(entry)
+		atomic.AddUint64(&l.processed, 1)
 	}
 }
 
-func (l *Logger) writeMessage(msg string) {
+func (l *Logger) writeMessage(entry Entry) {
+	formatted := l.formatter().Format(entry)
 	for _, strategy := range l.Strategies {
-		if n, err := strategy.Write([]byte(msg)); err != nil {
+		if leveled, ok := strategy.(LeveledWriter); ok && entry.Level < leveled.MinLevel() {
+			continue
+		}
+		if n, err := strategy.Write(formatted); err != nil {
+			l.recordWriteError()
 			log.Println(fmt.Sprintf("%d characters have been written. %s", n, err.Error()))
 		}
 	}
@@ -252,83 +324,165 @@ func (a *Log) GetLoggerInterfaceByType(loggerType uint) io.Writer {
 	return &DefaultStrategy{}
 }
 
+// allowed reports whether level passes the configured MinLevel threshold.
+// Checking this first is what lets callers skip fmt.Sprintf/newEntry/the
+// channel send entirely for suppressed messages.
+func (a *Log) allowed(level Level) bool {
+	return level >= a.config.MinLevel
+}
+
+// newEntry builds the Entry for msg, attaching the caller's file/line and
+// any fields accumulated via WithField/WithFields.
+func (a *Log) newEntry(level Level, msg string, skip int) Entry {
+	entry := Entry{
+		Time:    time.Now(),
+		Level:   level,
+		Message: msg,
+		Fields:  a.fields,
+	}
+	if _, fileName, fileLine, ok := runtime.Caller(skip); ok && a.config.IgnoreFileLine {
+		entry.File = fileName
+		entry.Line = fileLine
+	}
+	return entry
+}
+
+// send enqueues msg on the given logger type's channel at level, or logs
+// that the logger type is not configured.
+func (a *Log) send(loggerType uint, level Level, msg string, skip int) {
+	if logger := a.config.Loggers[loggerType]; logger != nil {
+		logger.enqueue(a.newEntry(level, msg, skip))
+	} else {
+		printNotConfiguredMessage(loggerType, skip)
+	}
+}
+
+// WithField returns a child logger that merges key/value into every
+// entry it logs afterwards.
+func (a *Log) WithField(key string, value interface{}) *Log {
+	return a.WithFields(map[string]interface{}{key: value})
+}
+
+// WithFields returns a child logger that merges fields into every entry
+// it logs afterwards, leaving the receiver untouched.
+func (a *Log) WithFields(fields map[string]interface{}) *Log {
+	merged := make(map[string]interface{}, len(a.fields)+len(fields))
+	for k, v := range a.fields {
+		merged[k] = v
+	}
+	for k, v := range fields {
+		merged[k] = v
+	}
+	return &Log{config: a.config, fields: merged}
+}
+
+// Trace method for recording the most verbose diagnostic messages
+func (a *Log) Trace(msg string) *Log {
+	if a.allowed(LevelTrace) {
+		a.send(LoggerInfo, LevelTrace, msg, 3)
+	}
+	return a
+}
+
+// Tracef method for recording formatted trace messages
+func (a *Log) Tracef(format string, p ...interface{}) *Log {
+	if a.allowed(LevelTrace) {
+		a.send(LoggerInfo, LevelTrace, fmt.Sprintf(format, p...), 3)
+	}
+	return a
+}
+
+// Debug method for recording debugging messages
+func (a *Log) Debug(msg string) *Log {
+	if a.allowed(LevelDebug) {
+		a.send(LoggerInfo, LevelDebug, msg, 3)
+	}
+	return a
+}
+
+// Debugf method for recording formatted debugging messages
+func (a *Log) Debugf(format string, p ...interface{}) *Log {
+	if a.allowed(LevelDebug) {
+		a.send(LoggerInfo, LevelDebug, fmt.Sprintf(format, p...), 3)
+	}
+	return a
+}
+
 // Info method for recording informational messages
 func (a *Log) Info(msg string) *Log {
-	if logger := a.config.Loggers[LoggerInfo]; logger != nil {
-		logger.Channel <- a.prepareLog(time.Now(), msg, 2)
-	} else {
-		printNotConfiguredMessage(LoggerInfo, 2)
+	if a.allowed(LevelInfo) {
+		a.send(LoggerInfo, LevelInfo, msg, 3)
 	}
 	return a
 }
 
 // Infof method of recording formatted informational messages
 func (a *Log) Infof(format string, p ...interface{}) *Log {
-	if logger := a.config.Loggers[LoggerInfo]; logger != nil {
-		logger.Channel <- a.prepareLog(time.Now(), fmt.Sprintf(format, p...), 2)
-	} else {
-		printNotConfiguredMessage(LoggerInfo, 2)
+	if a.allowed(LevelInfo) {
+		a.send(LoggerInfo, LevelInfo, fmt.Sprintf(format, p...), 3)
 	}
 	return a
 }
 
 // Warning method for recording warning messages
 func (a *Log) Warning(msg string) *Log {
-	if a.config.Loggers[LoggerWrn] != nil {
-		a.config.Loggers[LoggerWrn].Channel <- a.prepareLog(time.Now(), msg, 2)
-	} else {
-		printNotConfiguredMessage(LoggerWrn, 2)
+	if a.allowed(LevelWarn) {
+		a.send(LoggerWrn, LevelWarn, msg, 3)
 	}
 	return a
 }
 
 // Method for recording errors without stack
 func (a *Log) Error(err error) *Log {
-	if a.config.Loggers[LoggerErr] != nil {
-		if err != nil {
-			a.config.Loggers[LoggerErr].Channel <- a.prepareLog(time.Now(), err.Error(), 2)
-		}
-	} else {
-		printNotConfiguredMessage(LoggerErr, 2)
+	if err != nil && a.allowed(LevelError) {
+		a.send(LoggerErr, LevelError, err.Error(), 3)
 	}
 	return a
 }
 
 // ErrorDebug method for recording errors with stack
 func (a *Log) ErrorDebug(err error) *Log {
-	if a.config.Loggers[LoggerErr] != nil {
-		if err != nil {
-			msg := fmt.Sprintf(messageFormatErrorDebug, a.prepareLog(time.Now(), err.Error(), 2), string(debug.Stack()))
-			a.config.Loggers[LoggerErr].Channel <- msg
+	if err != nil && a.allowed(LevelError) {
+		if logger := a.config.Loggers[LoggerErr]; logger != nil {
+			entry := a.newEntry(LevelError, err.Error(), 2)
+			entry.Stack = string(debug.Stack())
+			logger.enqueue(entry)
+		} else {
+			printNotConfiguredMessage(LoggerErr, 2)
 		}
-	} else {
-		printNotConfiguredMessage(LoggerErr, 2)
 	}
 	return a
 }
 
-func (a *Log) getTimeFormat() string {
-	if format := a.config.TimeFormat; format != "" {
-		return format
+// Fatal method for recording a fatal message and terminating the process,
+// mirroring the standard library's log.Fatal.
+func (a *Log) Fatal(msg string) *Log {
+	if a.allowed(LevelFatal) {
+		a.send(LoggerErr, LevelFatal, msg, 3)
 	}
-	return time.RFC3339Nano
+	a.flushBeforeExit()
+	os.Exit(1)
+	return a
 }
 
-func (a *Log) prepareLog(time time.Time, msg string, skip int) string {
-	if _, fileName, fileLine, ok := runtime.Caller(skip); ok && a.config.IgnoreFileLine {
-		return fmt.Sprintf(
-			messageFormatWithFileLine,
-			time.Format(a.getTimeFormat()),
-			fileName,
-			fileLine,
-			msg,
-		)
-	}
-	return fmt.Sprintf(
-		messageFormatDefault,
-		time.Format(a.getTimeFormat()),
-		msg,
-	)
+// Fatalf method for recording a formatted fatal message and terminating
+// the process, mirroring the standard library's log.Fatalf.
+func (a *Log) Fatalf(format string, p ...interface{}) *Log {
+	if a.allowed(LevelFatal) {
+		a.send(LoggerErr, LevelFatal, fmt.Sprintf(format, p...), 3)
+	}
+	a.flushBeforeExit()
+	os.Exit(1)
+	return a
+}
+
+// flushBeforeExit drains the LoggerErr logger so the fatal message
+// reaches its strategies before os.Exit, instead of racing the
+// reader goroutine off the end of the process.
+func (a *Log) flushBeforeExit() {
+	if logger := a.config.Loggers[LoggerErr]; logger != nil {
+		_ = logger.sync()
+	}
 }
 
 func openFile(filePath string) (afero.File, error) {
@@ -0,0 +1,124 @@
+////////////////////////////////////////////////////////////////////////////////
+// Author:   Nikita Koryabkin
+// Email:    Nikita@Koryabk.in
+// Telegram: https://t.me/Apologiz
+////////////////////////////////////////////////////////////////////////////////
+
+package alog
+
+import (
+	"context"
+	"io"
+	"sync/atomic"
+	"time"
+)
+
+// syncPollInterval is how often (*Log).Sync polls for a Logger to finish
+// draining its Channel.
+const syncPollInterval = time.Millisecond
+
+// Syncer is implemented by strategies that buffer writes and need an
+// explicit flush before they're guaranteed durable.
+type Syncer interface {
+	Sync() error
+}
+
+// Flusher is implemented by strategies that buffer writes and expose the
+// flush under the more common Flush name.
+type Flusher interface {
+	Flush() error
+}
+
+func (l *Logger) isClosed() bool {
+	l.closeMu.RLock()
+	defer l.closeMu.RUnlock()
+	return l.closed
+}
+
+// close marks l as closed and closes Channel under closeMu, so no send in
+// enqueue can race this into a "send on closed channel" panic, then waits
+// for the reader goroutine to drain whatever was already queued, honoring
+// ctx's deadline. It then closes every strategy implementing io.Closer.
+func (l *Logger) close(ctx context.Context) error {
+	l.closeMu.Lock()
+	if l.closed {
+		l.closeMu.Unlock()
+		return nil
+	}
+	l.closed = true
+	close(l.Channel)
+	l.closeMu.Unlock()
+
+	drained := make(chan struct{})
+	go func() {
+		l.wg.Wait()
+		close(drained)
+	}()
+
+	select {
+	case <-drained:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	var firstErr error
+	for _, strategy := range l.Strategies {
+		if closer, ok := strategy.(io.Closer); ok {
+			if err := closer.Close(); err != nil && firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+	return firstErr
+}
+
+// sync blocks until every entry enqueued on l so far has been processed,
+// then flushes every strategy implementing Syncer or Flusher.
+func (l *Logger) sync() error {
+	for atomic.LoadUint64(&l.processed) < atomic.LoadUint64(&l.enqueued) {
+		time.Sleep(syncPollInterval)
+	}
+
+	var firstErr error
+	for _, strategy := range l.Strategies {
+		var err error
+		switch s := strategy.(type) {
+		case Syncer:
+			err = s.Sync()
+		case Flusher:
+			err = s.Flush()
+		}
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// Close closes every logger's Channel, waits (up to ctx's deadline) for
+// its reader goroutine to drain whatever was already queued, and closes
+// any strategy implementing io.Closer (FileStrategy,
+// RotatingFileStrategy, ConnStrategy).
+func (a *Log) Close(ctx context.Context) error {
+	var firstErr error
+	for _, logger := range a.config.Loggers {
+		if err := logger.close(ctx); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// Sync blocks until every logger's Channel is empty and every strategy
+// that implements Syncer/Flusher has been flushed. Call it before a
+// panic or at the end of main, when Close isn't appropriate because
+// logging continues afterwards.
+func (a *Log) Sync() error {
+	var firstErr error
+	for _, logger := range a.config.Loggers {
+		if err := logger.sync(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
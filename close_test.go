@@ -0,0 +1,68 @@
+package alog
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestCloseWhileLoggingDoesNotPanic hammers Info concurrently
+// with Close to guard against the "send on closed channel" panic that used
+// to be possible when enqueue raced (*Logger).close's close(Channel).
+func TestCloseWhileLoggingDoesNotPanic(t *testing.T) {
+	config := &Config{
+		Loggers: LoggerMap{
+			LoggerInfo: {Channel: make(chan Entry, 8), Strategies: []io.Writer{ioutil.Discard}},
+			LoggerWrn:  {Channel: make(chan Entry, 8), Strategies: []io.Writer{ioutil.Discard}},
+			LoggerErr:  {Channel: make(chan Entry, 8), Strategies: []io.Writer{ioutil.Discard}},
+		},
+	}
+	l := Create(config)
+
+	var wg sync.WaitGroup
+	stop := make(chan struct{})
+
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+					l.Info("still logging")
+				}
+			}
+		}()
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := l.Close(ctx); err != nil {
+		t.Fatalf("Close returned an error: %v", err)
+	}
+
+	close(stop)
+	wg.Wait()
+}
+
+// TestWriteAfterCloseReturnsError checks that the io.Writer adapter used
+// for third-party libraries reports the logger as closed instead of
+// panicking once Close has run.
+func TestWriteAfterCloseReturnsError(t *testing.T) {
+	logger := &Logger{Channel: make(chan Entry, 1), Strategies: []io.Writer{ioutil.Discard}}
+	logger.wg.Add(1)
+	go logger.reader()
+
+	if err := logger.close(context.Background()); err != nil {
+		t.Fatalf("close returned an error: %v", err)
+	}
+
+	if _, err := logger.Write([]byte("after close")); err == nil {
+		t.Fatal("expected Write to report the logger as closed")
+	}
+}
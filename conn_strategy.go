@@ -0,0 +1,132 @@
+////////////////////////////////////////////////////////////////////////////////
+// Author:   Nikita Koryabkin
+// Email:    Nikita@Koryabk.in
+// Telegram: https://t.me/Apologiz
+////////////////////////////////////////////////////////////////////////////////
+
+package alog
+
+import (
+	"io"
+	"net"
+	"sync"
+	"time"
+)
+
+// connKeepaliveInterval is how often the keepalive goroutine checks
+// whether a dropped connection needs to be redialed.
+const connKeepaliveInterval = 30 * time.Second
+
+// ConnOpts configures a ConnStrategy.
+type ConnOpts struct {
+	// Reconnect redials network/addr after a write error instead of
+	// leaving the strategy broken for the rest of the process.
+	Reconnect bool
+	// ReconnectOnMsg dials a fresh connection for every message and
+	// closes it right after sending, instead of keeping one open.
+	ReconnectOnMsg bool
+	// DialTimeout bounds how long dialing network/addr may take.
+	// Zero means no timeout.
+	DialTimeout time.Duration
+	// MinLevel is the severity below which the strategy is skipped.
+	MinLevel Level
+}
+
+// ConnStrategy logging strategy over a TCP/UDP/unix socket connection
+type ConnStrategy struct {
+	network string
+	addr    string
+	opts    ConnOpts
+
+	mu   sync.Mutex
+	conn net.Conn
+
+	closeOnce sync.Once
+	done      chan struct{}
+
+	io.Writer
+}
+
+// GetConnStrategy connection write strategy
+func GetConnStrategy(network, addr string, opts ConnOpts) io.Writer {
+	s := &ConnStrategy{network: network, addr: addr, opts: opts, done: make(chan struct{})}
+	if opts.Reconnect && !opts.ReconnectOnMsg {
+		go s.keepalive()
+	}
+	return s
+}
+
+// MinLevel returns the severity below which the strategy is skipped,
+// implementing LeveledWriter.
+func (s *ConnStrategy) MinLevel() Level {
+	return s.opts.MinLevel
+}
+
+func (s *ConnStrategy) dial() (net.Conn, error) {
+	return net.DialTimeout(s.network, s.addr, s.opts.DialTimeout)
+}
+
+func (s *ConnStrategy) Write(p []byte) (n int, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.conn == nil || s.opts.ReconnectOnMsg {
+		if s.conn, err = s.dial(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err = s.conn.Write(append(p, '\n'))
+	if err != nil {
+		if s.opts.Reconnect {
+			_ = s.conn.Close()
+			s.conn = nil
+		}
+		return n, err
+	}
+
+	if s.opts.ReconnectOnMsg {
+		err = s.conn.Close()
+		s.conn = nil
+	}
+
+	return n, err
+}
+
+// keepalive redials the connection in the background so the next Write
+// doesn't pay the dial cost after a drop. It only runs when
+// Reconnect is set without ReconnectOnMsg, since ReconnectOnMsg already
+// dials fresh on every message.
+func (s *ConnStrategy) keepalive() {
+	ticker := time.NewTicker(connKeepaliveInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			s.mu.Lock()
+			if s.conn == nil {
+				if conn, err := s.dial(); err == nil {
+					s.conn = conn
+				}
+			}
+			s.mu.Unlock()
+		case <-s.done:
+			return
+		}
+	}
+}
+
+// Close closes the open connection, if any, and stops the keepalive
+// goroutine, implementing io.Closer.
+func (s *ConnStrategy) Close() error {
+	s.closeOnce.Do(func() { close(s.done) })
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.conn == nil {
+		return nil
+	}
+	err := s.conn.Close()
+	s.conn = nil
+	return err
+}
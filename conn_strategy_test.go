@@ -0,0 +1,82 @@
+package alog
+
+import (
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newAcceptCounter starts a TCP listener that drains and counts every
+// connection it accepts, for asserting how many times ConnStrategy dialed.
+func newAcceptCounter(t *testing.T) (addr string, accepts *int32, closeFn func()) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	var count int32
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			atomic.AddInt32(&count, 1)
+			go func(c net.Conn) {
+				buf := make([]byte, 1024)
+				for {
+					if _, err := c.Read(buf); err != nil {
+						return
+					}
+				}
+			}(conn)
+		}
+	}()
+	return ln.Addr().String(), &count, func() { ln.Close() }
+}
+
+func waitForAccepts(t *testing.T, accepts *int32, want int32) {
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		if atomic.LoadInt32(accepts) == want {
+			return
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatalf("timed out waiting for %d accepted connections, got %d", want, atomic.LoadInt32(accepts))
+}
+
+func TestConnStrategyReusesConnectionAcrossWrites(t *testing.T) {
+	addr, accepts, closeFn := newAcceptCounter(t)
+	defer closeFn()
+
+	w := GetConnStrategy("tcp", addr, ConnOpts{})
+	s := w.(*ConnStrategy)
+	defer s.Close()
+
+	for i := 0; i < 3; i++ {
+		if _, err := s.Write([]byte("hello")); err != nil {
+			t.Fatalf("write %d: %v", i, err)
+		}
+	}
+
+	waitForAccepts(t, accepts, 1)
+}
+
+func TestConnStrategyReconnectOnMsgDialsPerWrite(t *testing.T) {
+	addr, accepts, closeFn := newAcceptCounter(t)
+	defer closeFn()
+
+	w := GetConnStrategy("tcp", addr, ConnOpts{ReconnectOnMsg: true})
+	s := w.(*ConnStrategy)
+	defer s.Close()
+
+	const writes = 3
+	for i := 0; i < writes; i++ {
+		if _, err := s.Write([]byte("hello")); err != nil {
+			t.Fatalf("write %d: %v", i, err)
+		}
+	}
+
+	waitForAccepts(t, accepts, writes)
+}
@@ -0,0 +1,109 @@
+////////////////////////////////////////////////////////////////////////////////
+// Author:   Nikita Koryabkin
+// Email:    Nikita@Koryabk.in
+// Telegram: https://t.me/Apologiz
+////////////////////////////////////////////////////////////////////////////////
+
+package alog
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+)
+
+const (
+	messageFormatDefault      = "%s;%s\n"
+	messageFormatErrorDebug   = "%s\n%s\n---\n\n"
+	messageFormatWithFileLine = "%s;%s:%d;%s\n"
+)
+
+// Entry is a single log record queued on a Logger.Channel. A Formatter
+// renders it to bytes before it reaches the logger's strategies.
+type Entry struct {
+	Time    time.Time
+	Level   Level
+	Message string
+	File    string
+	Line    int
+	Fields  map[string]interface{}
+	Stack   string
+}
+
+// Formatter renders an Entry for a strategy to write.
+type Formatter interface {
+	Format(entry Entry) []byte
+}
+
+// TextFormatter renders an Entry the way alog has always written plain
+// text: "time;message", or "time;file:line;message" when Entry.File is
+// set. This is the zero-value Formatter used when a Logger has none.
+type TextFormatter struct {
+	// TimeFormat is passed to Entry.Time.Format. Empty means
+	// time.RFC3339Nano.
+	TimeFormat string
+}
+
+func (f *TextFormatter) timeFormat() string {
+	if f.TimeFormat != "" {
+		return f.TimeFormat
+	}
+	return time.RFC3339Nano
+}
+
+// Format implements Formatter.
+func (f *TextFormatter) Format(entry Entry) []byte {
+	var base string
+	if entry.File != "" {
+		base = fmt.Sprintf(messageFormatWithFileLine, entry.Time.Format(f.timeFormat()), entry.File, entry.Line, entry.Message)
+	} else {
+		base = fmt.Sprintf(messageFormatDefault, entry.Time.Format(f.timeFormat()), entry.Message)
+	}
+	if entry.Stack != "" {
+		return []byte(fmt.Sprintf(messageFormatErrorDebug, strings.TrimSuffix(base, "\n"), entry.Stack))
+	}
+	return []byte(base)
+}
+
+// jsonEntry is the wire shape JSONFormatter emits; it mirrors Entry with
+// json tags and level rendered as its name rather than its numeric value.
+type jsonEntry struct {
+	Time    string                 `json:"time"`
+	Level   string                 `json:"level"`
+	Message string                 `json:"message"`
+	File    string                 `json:"file,omitempty"`
+	Line    int                    `json:"line,omitempty"`
+	Fields  map[string]interface{} `json:"fields,omitempty"`
+	Stack   string                 `json:"stack,omitempty"`
+}
+
+// JSONFormatter renders an Entry as a single line of JSON.
+type JSONFormatter struct {
+	// TimeFormat is passed to Entry.Time.Format. Empty means
+	// time.RFC3339Nano.
+	TimeFormat string
+}
+
+// Format implements Formatter.
+func (f *JSONFormatter) Format(entry Entry) []byte {
+	timeFormat := f.TimeFormat
+	if timeFormat == "" {
+		timeFormat = time.RFC3339Nano
+	}
+	b, err := json.Marshal(jsonEntry{
+		Time:    entry.Time.Format(timeFormat),
+		Level:   LevelName(entry.Level),
+		Message: entry.Message,
+		File:    entry.File,
+		Line:    entry.Line,
+		Fields:  entry.Fields,
+		Stack:   entry.Stack,
+	})
+	if err != nil {
+		log.Println(err)
+		return nil
+	}
+	return append(b, '\n')
+}
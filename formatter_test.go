@@ -0,0 +1,88 @@
+package alog
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestJSONFormatterFormat(t *testing.T) {
+	f := &JSONFormatter{}
+	entry := Entry{
+		Time:    time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+		Level:   LevelWarn,
+		Message: "disk almost full",
+		Fields:  map[string]interface{}{"percent": 92},
+	}
+
+	var got jsonEntry
+	if err := json.Unmarshal(f.Format(entry), &got); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if got.Level != "WARN" {
+		t.Fatalf("expected level WARN, got %q", got.Level)
+	}
+	if got.Message != "disk almost full" {
+		t.Fatalf("expected message %q, got %q", "disk almost full", got.Message)
+	}
+	if got.Fields["percent"].(float64) != 92 {
+		t.Fatalf("expected fields.percent 92, got %v", got.Fields["percent"])
+	}
+}
+
+// captureWriter records the last slice written to it, for asserting on a
+// Logger's formatted output.
+type captureWriter struct {
+	mu  sync.Mutex
+	buf []byte
+}
+
+func (c *captureWriter) Write(p []byte) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.buf = append(c.buf[:0], p...)
+	return len(p), nil
+}
+
+func (c *captureWriter) bytes() []byte {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.buf
+}
+
+func TestWithFieldMergesIntoEntryWithoutMutatingParent(t *testing.T) {
+	captured := &captureWriter{}
+	config := &Config{
+		Loggers: LoggerMap{
+			LoggerInfo: {Channel: make(chan Entry, 2), Strategies: []io.Writer{captured}, Formatter: &JSONFormatter{}},
+		},
+	}
+	a := Create(config)
+
+	a.WithField("requestID", "abc123").Info("done")
+	if err := a.Sync(); err != nil {
+		t.Fatalf("Sync: %v", err)
+	}
+
+	var got jsonEntry
+	if err := json.Unmarshal(captured.bytes(), &got); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if got.Fields["requestID"] != "abc123" {
+		t.Fatalf("expected fields.requestID abc123, got %+v", got.Fields)
+	}
+
+	a.Info("plain")
+	if err := a.Sync(); err != nil {
+		t.Fatalf("Sync: %v", err)
+	}
+	got = jsonEntry{}
+	if err := json.Unmarshal(captured.bytes(), &got); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if _, ok := got.Fields["requestID"]; ok {
+		t.Fatal("WithField should return a child logger, not mutate the parent")
+	}
+}
@@ -0,0 +1,42 @@
+////////////////////////////////////////////////////////////////////////////////
+// Author:   Nikita Koryabkin
+// Email:    Nikita@Koryabk.in
+// Telegram: https://t.me/Apologiz
+////////////////////////////////////////////////////////////////////////////////
+
+package alog
+
+// Level describes the severity of a log message.
+type Level uint
+
+// Severity levels, from the most to the least verbose.
+const (
+	LevelTrace Level = iota
+	LevelDebug
+	LevelInfo
+	LevelWarn
+	LevelError
+	LevelFatal
+)
+
+var levelName = map[Level]string{
+	LevelTrace: "TRACE",
+	LevelDebug: "DEBUG",
+	LevelInfo:  "INFO",
+	LevelWarn:  "WARN",
+	LevelError: "ERROR",
+	LevelFatal: "FATAL",
+}
+
+// LevelName returns a name for the level.
+// It returns the empty string if the level is unknown.
+func LevelName(level Level) string {
+	return levelName[level]
+}
+
+// LeveledWriter is implemented by strategies that only want to receive
+// messages at or above a certain severity, e.g. an EmailStrategy that
+// should only fire on ERROR and above.
+type LeveledWriter interface {
+	MinLevel() Level
+}
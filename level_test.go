@@ -0,0 +1,79 @@
+package alog
+
+import (
+	"io"
+	"io/ioutil"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+// stringerSpy records whether fmt ever called its String method, so a
+// test can tell whether a suppressed Tracef/Debugf/... actually ran
+// fmt.Sprintf on its arguments instead of just skipping the channel send.
+type stringerSpy struct {
+	called *int32
+}
+
+func (s stringerSpy) String() string {
+	atomic.AddInt32(s.called, 1)
+	return "spy"
+}
+
+func TestMinLevelSuppressesBelowThresholdWithoutFormatting(t *testing.T) {
+	config := &Config{
+		MinLevel: LevelInfo,
+		Loggers: LoggerMap{
+			LoggerInfo: {Channel: make(chan Entry, 2), Strategies: []io.Writer{ioutil.Discard}},
+		},
+	}
+	a := Create(config)
+
+	var traceCalled, infoCalled int32
+	a.Tracef("%v", stringerSpy{&traceCalled})
+	a.Infof("%v", stringerSpy{&infoCalled})
+
+	if err := a.Sync(); err != nil {
+		t.Fatalf("Sync: %v", err)
+	}
+
+	if atomic.LoadInt32(&traceCalled) != 0 {
+		t.Fatal("Tracef below MinLevel should not have formatted its arguments")
+	}
+	if atomic.LoadInt32(&infoCalled) != 1 {
+		t.Fatal("Infof at MinLevel should have formatted its arguments")
+	}
+}
+
+// levelGatedWriter is a LeveledWriter that records how many entries
+// actually reached it, for asserting that writeMessage honors per-strategy
+// MinLevel.
+type levelGatedWriter struct {
+	minLevel Level
+
+	mu      sync.Mutex
+	written int
+}
+
+func (w *levelGatedWriter) MinLevel() Level { return w.minLevel }
+
+func (w *levelGatedWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.written++
+	return len(p), nil
+}
+
+func TestWriteMessageSkipsStrategiesBelowTheirMinLevel(t *testing.T) {
+	strategy := &levelGatedWriter{minLevel: LevelError}
+	l := &Logger{Strategies: []io.Writer{strategy}}
+
+	l.writeMessage(Entry{Level: LevelWarn, Message: "should be skipped"})
+	l.writeMessage(Entry{Level: LevelError, Message: "should be written"})
+
+	strategy.mu.Lock()
+	defer strategy.mu.Unlock()
+	if strategy.written != 1 {
+		t.Fatalf("expected exactly 1 write to reach the ERROR-only strategy, got %d", strategy.written)
+	}
+}
@@ -0,0 +1,141 @@
+////////////////////////////////////////////////////////////////////////////////
+// Author:   Nikita Koryabkin
+// Email:    Nikita@Koryabk.in
+// Telegram: https://t.me/Apologiz
+////////////////////////////////////////////////////////////////////////////////
+
+package alog
+
+import (
+	"fmt"
+	"log"
+	"sync/atomic"
+	"time"
+)
+
+// dropWarnInterval rate-limits the "entries are being dropped" log line so
+// a sustained overflow doesn't itself flood the console.
+const dropWarnInterval = time.Second
+
+// OverflowPolicy controls what happens when a Logger's Channel buffer is
+// full, instead of the caller blocking until the reader goroutine catches
+// up.
+type OverflowPolicy uint
+
+const (
+	// OverflowBlock blocks the caller until there is room on the
+	// channel. This is the original, pre-OverflowPolicy behavior.
+	OverflowBlock OverflowPolicy = iota
+	// OverflowDropNewest discards the entry currently being enqueued.
+	OverflowDropNewest
+	// OverflowDropOldest discards the oldest queued entry to make room
+	// for the one being enqueued.
+	OverflowDropOldest
+	// OverflowTimeout waits up to Config.OverflowTimeout for room on the
+	// channel before discarding the entry being enqueued.
+	OverflowTimeout
+)
+
+// LoggerStats is a snapshot of a Logger's enqueue/write counters,
+// returned by (*Log).Stats().
+type LoggerStats struct {
+	Enqueued    uint64
+	Dropped     uint64
+	WriteErrors uint64
+}
+
+// enqueue sends entry on l.Channel honoring l.overflowPolicy, and updates
+// the Enqueued/Dropped stats accordingly. It holds closeMu for the
+// duration of the send so it can never race (*Logger).close's
+// close(l.Channel) into a "send on closed channel" panic: if l is
+// already closed the entry is silently dropped instead of sent.
+func (l *Logger) enqueue(entry Entry) {
+	l.closeMu.RLock()
+	defer l.closeMu.RUnlock()
+
+	if l.closed {
+		return
+	}
+
+	switch l.overflowPolicy {
+	case OverflowDropNewest:
+		select {
+		case l.Channel <- entry:
+			atomic.AddUint64(&l.enqueued, 1)
+		default:
+			l.recordDrop()
+		}
+	case OverflowDropOldest:
+		select {
+		case l.Channel <- entry:
+			atomic.AddUint64(&l.enqueued, 1)
+			return
+		default:
+		}
+		select {
+		case <-l.Channel:
+			// The evicted entry was already counted in enqueued when it
+			// was queued, but the reader will never process it, so also
+			// count it as processed: otherwise Sync()'s drain condition
+			// (processed >= enqueued) never converges.
+			atomic.AddUint64(&l.processed, 1)
+			l.recordDrop()
+		default:
+		}
+		select {
+		case l.Channel <- entry:
+			atomic.AddUint64(&l.enqueued, 1)
+		default:
+			l.recordDrop()
+		}
+	case OverflowTimeout:
+		select {
+		case l.Channel <- entry:
+			atomic.AddUint64(&l.enqueued, 1)
+		case <-time.After(l.overflowTimeout):
+			l.recordDrop()
+		}
+	default: // OverflowBlock
+		l.Channel <- entry
+		atomic.AddUint64(&l.enqueued, 1)
+	}
+}
+
+func (l *Logger) recordDrop() {
+	atomic.AddUint64(&l.dropped, 1)
+	l.warnDrop()
+}
+
+func (l *Logger) recordWriteError() {
+	atomic.AddUint64(&l.writeErrors, 1)
+}
+
+// warnDrop emits a rate-limited warning line when entries are dropped, so
+// a sustained overflow is visible without flooding the console.
+func (l *Logger) warnDrop() {
+	l.dropWarnMu.Lock()
+	defer l.dropWarnMu.Unlock()
+	if time.Since(l.lastDropWarn) < dropWarnInterval {
+		return
+	}
+	l.lastDropWarn = time.Now()
+	log.Println(fmt.Sprintf("alog: dropping log entries, %d dropped so far", atomic.LoadUint64(&l.dropped)))
+}
+
+func (l *Logger) stats() LoggerStats {
+	return LoggerStats{
+		Enqueued:    atomic.LoadUint64(&l.enqueued),
+		Dropped:     atomic.LoadUint64(&l.dropped),
+		WriteErrors: atomic.LoadUint64(&l.writeErrors),
+	}
+}
+
+// Stats returns a snapshot of Enqueued/Dropped/WriteErrors counters for
+// every configured logger type.
+func (a *Log) Stats() map[uint]LoggerStats {
+	stats := make(map[uint]LoggerStats, len(a.config.Loggers))
+	for loggerType, logger := range a.config.Loggers {
+		stats[loggerType] = logger.stats()
+	}
+	return stats
+}
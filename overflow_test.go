@@ -0,0 +1,78 @@
+package alog
+
+import (
+	"io"
+	"sync"
+	"testing"
+	"time"
+)
+
+type countingStrategy struct {
+	mu    sync.Mutex
+	lines int
+}
+
+func (s *countingStrategy) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.lines++
+	return len(p), nil
+}
+
+// TestOverflowDropOldestAdvancesSync reproduces the DropOldest eviction
+// bug: filling a small buffer without a reader running forces evictions,
+// and Sync must still converge afterwards instead of waiting forever for
+// an entry the reader will never see.
+func TestOverflowDropOldestAdvancesSync(t *testing.T) {
+	strategy := &countingStrategy{}
+	l := &Logger{
+		Channel:        make(chan Entry, 2),
+		Strategies:     []io.Writer{strategy},
+		Formatter:      &TextFormatter{},
+		overflowPolicy: OverflowDropOldest,
+	}
+
+	for i := 0; i < 5; i++ {
+		l.enqueue(Entry{Message: "x"})
+	}
+
+	l.wg.Add(1)
+	go l.reader()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_ = l.sync()
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("sync() did not converge after a DropOldest eviction")
+	}
+
+	if stats := l.stats(); stats.Dropped == 0 {
+		t.Fatalf("expected at least one recorded drop, got %+v", stats)
+	}
+}
+
+// TestOverflowDropNewestRecordsDrop checks that an entry discarded
+// because the buffer is full under DropNewest is both counted and never
+// blocks the caller.
+func TestOverflowDropNewestRecordsDrop(t *testing.T) {
+	l := &Logger{
+		Channel:        make(chan Entry, 1),
+		overflowPolicy: OverflowDropNewest,
+	}
+
+	l.enqueue(Entry{Message: "first"})
+	l.enqueue(Entry{Message: "second"})
+
+	stats := l.stats()
+	if stats.Enqueued != 1 {
+		t.Fatalf("expected 1 enqueued, got %d", stats.Enqueued)
+	}
+	if stats.Dropped != 1 {
+		t.Fatalf("expected 1 dropped, got %d", stats.Dropped)
+	}
+}
@@ -0,0 +1,226 @@
+////////////////////////////////////////////////////////////////////////////////
+// Author:   Nikita Koryabkin
+// Email:    Nikita@Koryabk.in
+// Telegram: https://t.me/Apologiz
+////////////////////////////////////////////////////////////////////////////////
+
+package alog
+
+import (
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/afero"
+)
+
+// rotatingFileJanitorInterval is how often the janitor goroutine checks
+// for backups to prune.
+const rotatingFileJanitorInterval = time.Hour
+
+// backupTimeFormat names a rotated backup after the moment it was cut.
+const backupTimeFormat = "2006-01-02T15-04-05.000"
+
+// RotatingFileOpts configures a RotatingFileStrategy.
+type RotatingFileOpts struct {
+	// MaxSizeBytes rotates the file once writing would grow it past this
+	// size. Zero disables size-based rotation.
+	MaxSizeBytes int64
+	// MaxAgeDays prunes backups older than this many days.
+	// Zero disables age-based pruning.
+	MaxAgeDays int
+	// MaxBackups caps how many backups are kept; the oldest are pruned
+	// first. Zero disables the cap.
+	MaxBackups int
+	// Compress gzips a backup right after it is rotated out.
+	Compress bool
+	// MinLevel is the severity below which the strategy is skipped.
+	MinLevel Level
+}
+
+// RotatingFileStrategy logging strategy in the file, rotating it by size
+// and pruning old backups by age/count, optionally gzipping them.
+type RotatingFileStrategy struct {
+	filePath string
+	opts     RotatingFileOpts
+
+	mu   sync.Mutex
+	file afero.File
+	size int64
+
+	closeOnce sync.Once
+	done      chan struct{}
+
+	io.Writer
+}
+
+// GetRotatingFileStrategy file write strategy with size/age-based
+// rotation. See RotatingFileOpts for the rotation knobs.
+func GetRotatingFileStrategy(filePath string, opts RotatingFileOpts) io.Writer {
+	s := &RotatingFileStrategy{filePath: filePath, opts: opts, done: make(chan struct{})}
+	if addDirectory(filePath) == nil {
+		if file, err := openFile(filePath); err == nil {
+			s.file = file
+			if info, err := file.Stat(); err == nil {
+				s.size = info.Size()
+			}
+		}
+	}
+	go s.janitor()
+	return s
+}
+
+// MinLevel returns the severity below which the strategy is skipped,
+// implementing LeveledWriter.
+func (s *RotatingFileStrategy) MinLevel() Level {
+	return s.opts.MinLevel
+}
+
+func (s *RotatingFileStrategy) Write(p []byte) (n int, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.file == nil {
+		return 0, errors.New("file not defined")
+	}
+
+	if s.opts.MaxSizeBytes > 0 && s.size+int64(len(p)) > s.opts.MaxSizeBytes {
+		if err := s.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err = s.file.Write(p)
+	s.size += int64(n)
+	return n, err
+}
+
+// rotate closes the current file, renames it to a timestamped backup,
+// and opens a fresh file in its place. Callers must hold s.mu.
+func (s *RotatingFileStrategy) rotate() error {
+	if err := s.file.Close(); err != nil {
+		return err
+	}
+
+	backupPath := fmt.Sprintf("%s.%s", s.filePath, time.Now().Format(backupTimeFormat))
+	if err := fs.Rename(s.filePath, backupPath); err != nil {
+		return err
+	}
+
+	file, err := openFile(s.filePath)
+	if err != nil {
+		return err
+	}
+	s.file = file
+	s.size = 0
+
+	if s.opts.Compress {
+		go compressBackup(backupPath)
+	}
+
+	return nil
+}
+
+// compressBackup gzips path in place and removes the uncompressed backup.
+func compressBackup(path string) {
+	src, err := fs.Open(path)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	defer src.Close()
+
+	dst, err := fs.OpenFile(path+".gz", fileOptions, filePermission)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	defer dst.Close()
+
+	gz := gzip.NewWriter(dst)
+	if _, err := io.Copy(gz, src); err != nil {
+		log.Println(err)
+		return
+	}
+	if err := gz.Close(); err != nil {
+		log.Println(err)
+		return
+	}
+	if err := fs.Remove(path); err != nil {
+		log.Println(err)
+	}
+}
+
+// janitor prunes backups older than MaxAgeDays or beyond MaxBackups on a
+// fixed interval. It runs once per strategy and exits early if neither
+// limit is configured.
+func (s *RotatingFileStrategy) janitor() {
+	if s.opts.MaxAgeDays <= 0 && s.opts.MaxBackups <= 0 {
+		return
+	}
+	ticker := time.NewTicker(rotatingFileJanitorInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			s.prune()
+		case <-s.done:
+			return
+		}
+	}
+}
+
+// Close closes the underlying file and stops the janitor goroutine,
+// implementing io.Closer.
+func (s *RotatingFileStrategy) Close() error {
+	s.closeOnce.Do(func() { close(s.done) })
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.file == nil {
+		return nil
+	}
+	return s.file.Close()
+}
+
+func (s *RotatingFileStrategy) prune() {
+	dir, name := filepath.Split(s.filePath)
+	if dir == "" {
+		dir = "."
+	}
+
+	infos, err := afero.ReadDir(fs, dir)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+
+	var backups []os.FileInfo
+	for _, info := range infos {
+		if info.Name() != name && strings.HasPrefix(info.Name(), name+".") {
+			backups = append(backups, info)
+		}
+	}
+	sort.Slice(backups, func(i, j int) bool {
+		return backups[i].ModTime().Before(backups[j].ModTime())
+	})
+
+	cutoff := time.Now().AddDate(0, 0, -s.opts.MaxAgeDays)
+	for i, info := range backups {
+		expired := s.opts.MaxAgeDays > 0 && info.ModTime().Before(cutoff)
+		tooMany := s.opts.MaxBackups > 0 && len(backups)-i > s.opts.MaxBackups
+		if expired || tooMany {
+			if err := fs.Remove(filepath.Join(dir, info.Name())); err != nil {
+				log.Println(err)
+			}
+		}
+	}
+}
@@ -0,0 +1,145 @@
+package alog
+
+import (
+	"compress/gzip"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/afero"
+)
+
+// withMemMapFs swaps the package-level fs for an in-memory afero.Fs for
+// the duration of the test, matching the afero.Fs abstraction
+// RotatingFileStrategy is built on.
+func withMemMapFs(t *testing.T) {
+	orig := fs
+	fs = afero.NewMemMapFs()
+	t.Cleanup(func() { fs = orig })
+}
+
+func backupNames(t *testing.T, dir, base string) []string {
+	infos, err := afero.ReadDir(fs, dir)
+	if err != nil {
+		t.Fatalf("ReadDir: %v", err)
+	}
+	var names []string
+	for _, info := range infos {
+		if info.Name() != base && strings.HasPrefix(info.Name(), base+".") {
+			names = append(names, info.Name())
+		}
+	}
+	return names
+}
+
+func TestRotatingFileStrategyRotatesOnSize(t *testing.T) {
+	withMemMapFs(t)
+
+	path := "/logs/app.log"
+	w := GetRotatingFileStrategy(path, RotatingFileOpts{MaxSizeBytes: 10})
+	s := w.(*RotatingFileStrategy)
+	defer s.Close()
+
+	if _, err := s.Write([]byte("0123456789")); err != nil {
+		t.Fatalf("first write: %v", err)
+	}
+	if backups := backupNames(t, "/logs", "app.log"); len(backups) != 0 {
+		t.Fatalf("expected no rotation yet, got backups %v", backups)
+	}
+
+	if _, err := s.Write([]byte("rotate-me")); err != nil {
+		t.Fatalf("second write: %v", err)
+	}
+
+	backups := backupNames(t, "/logs", "app.log")
+	if len(backups) != 1 {
+		t.Fatalf("expected 1 backup after exceeding MaxSizeBytes, got %v", backups)
+	}
+}
+
+func TestRotatingFileStrategyMaxBackupsKeepsNewest(t *testing.T) {
+	withMemMapFs(t)
+
+	path := "/logs/app.log"
+	w := GetRotatingFileStrategy(path, RotatingFileOpts{MaxSizeBytes: 1, MaxBackups: 2})
+	s := w.(*RotatingFileStrategy)
+	defer s.Close()
+
+	for i := 0; i < 4; i++ {
+		if _, err := s.Write([]byte("xx")); err != nil {
+			t.Fatalf("write %d: %v", i, err)
+		}
+		// backups are timestamped to millisecond resolution; space
+		// rotations out so each gets a distinct backup name.
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	s.prune()
+
+	backups := backupNames(t, "/logs", "app.log")
+	if len(backups) != 2 {
+		t.Fatalf("expected MaxBackups=2 to retain exactly 2 backups, got %v", backups)
+	}
+}
+
+func TestRotatingFileStrategyCompressGzipsAndRemovesBackup(t *testing.T) {
+	withMemMapFs(t)
+
+	path := "/logs/app.log"
+	w := GetRotatingFileStrategy(path, RotatingFileOpts{MaxSizeBytes: 1, Compress: true})
+	s := w.(*RotatingFileStrategy)
+	defer s.Close()
+
+	if _, err := s.Write([]byte("a")); err != nil {
+		t.Fatalf("first write: %v", err)
+	}
+	if _, err := s.Write([]byte("b")); err != nil {
+		t.Fatalf("second write: %v", err)
+	}
+
+	var gzName string
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		for _, name := range backupNames(t, "/logs", "app.log") {
+			if strings.HasSuffix(name, ".gz") {
+				gzName = name
+			}
+		}
+		// compressBackup creates the .gz before filling it and removes the
+		// uncompressed backup last, so wait for that removal too.
+		if gzName != "" {
+			if exists, _ := afero.Exists(fs, filepath.Join("/logs", strings.TrimSuffix(gzName, ".gz"))); !exists {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if gzName == "" {
+		t.Fatal("expected a .gz backup to appear once compression finished")
+	}
+
+	uncompressed := strings.TrimSuffix(gzName, ".gz")
+	if exists, _ := afero.Exists(fs, filepath.Join("/logs", uncompressed)); exists {
+		t.Fatalf("expected uncompressed backup %q to be removed after compression", uncompressed)
+	}
+
+	gzFile, err := fs.Open(filepath.Join("/logs", gzName))
+	if err != nil {
+		t.Fatalf("open gz backup: %v", err)
+	}
+	defer gzFile.Close()
+	gz, err := gzip.NewReader(gzFile)
+	if err != nil {
+		t.Fatalf("gzip.NewReader: %v", err)
+	}
+	defer gz.Close()
+	content, err := ioutil.ReadAll(gz)
+	if err != nil {
+		t.Fatalf("read gz content: %v", err)
+	}
+	if string(content) != "a" {
+		t.Fatalf("expected compressed backup to contain %q, got %q", "a", string(content))
+	}
+}